@@ -7,22 +7,81 @@ import (
 	"os"
 	"os/exec"
 	"path"
+	"path/filepath"
+	"sort"
 	"strconv"
+	"time"
 
 	drtodo "github.com/eriktate/dr-todo"
 	"github.com/urfave/cli/v2"
 )
 
 var homePath string
+var storeKind string
+var formatKind string
+
+// newStore builds the Store selected by the --store flag, rooted at
+// homePath.
+func newStore() (drtodo.Store, error) {
+	switch storeKind {
+	case "disk", "":
+		return drtodo.NewDiskStore(homePath), nil
+	case "git":
+		return drtodo.NewGitStore(homePath)
+	default:
+		return nil, fmt.Errorf("unknown store '%s', expected 'disk' or 'git'", storeKind)
+	}
+}
+
+// newDrTodo builds a DrTodo using the --store and --format flags.
+func newDrTodo() (drtodo.DrTodo, error) {
+	store, err := newStore()
+	if err != nil {
+		return drtodo.DrTodo{}, err
+	}
+
+	format, err := drtodo.FormatForExt(formatKind)
+	if err != nil {
+		return drtodo.DrTodo{}, fmt.Errorf("unknown format '%s': %w", formatKind, err)
+	}
+
+	return drtodo.NewWithStore(store, format), nil
+}
+
+// cutoffFor resolves the --since flag: an explicit date if provided, or an
+// auto-detected one based on the last fully-completed list. A store with
+// no lists at all has nothing to carry forward, so it resolves to "now".
+func cutoffFor(since string, store drtodo.Store) (time.Time, error) {
+	if since != "" {
+		cutoff, err := drtodo.ParseDate(since)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid --since date '%s': %w", since, err)
+		}
+
+		return cutoff, nil
+	}
+
+	cutoff, err := drtodo.DetectCutoff(store)
+	if err != nil {
+		if errors.Is(err, drtodo.ErrListNotFound) {
+			return time.Now(), nil
+		}
+
+		return time.Time{}, fmt.Errorf("auto-detecting --since cutoff: %w", err)
+	}
+
+	return cutoff, nil
+}
 
 func HandleNew() *cli.Command {
 	var skipEdit bool
+	var since string
 
 	return &cli.Command{
 		Name:        "new",
 		Usage:       "Create a new list for today",
 		Description: "Prints an error if the list already exists. If the --edit flag is provided, attempts to open $EDITOR regardless of error response",
-		UsageText:   "dr-todo [global options] new [--skip-edit]",
+		UsageText:   "dr-todo [global options] new [--skip-edit] [--since YYYY-MM-DD]",
 		Flags: []cli.Flag{
 			&cli.BoolFlag{
 				Name:        "skip-edit",
@@ -30,14 +89,35 @@ func HandleNew() *cli.Command {
 				Usage:       "Skips opening the TODO file with $EDITOR after creation",
 				Destination: &skipEdit,
 			},
+			&cli.StringFlag{
+				Name:        "since",
+				Value:       "",
+				Usage:       "merges every unfinished todo from lists dated on or after YYYY-MM-DD, instead of just the latest one. Auto-detected from the last fully-completed list when omitted",
+				Destination: &since,
+			},
 		},
 		Action: func(ctx *cli.Context) error {
-			dt := drtodo.New(homePath)
-			path, err := dt.CreateToday()
+			dt, err := newDrTodo()
+			if err != nil {
+				return err
+			}
+
+			store, err := newStore()
+			if err != nil {
+				return err
+			}
+
+			cutoff, err := cutoffFor(since, store)
+			if err != nil {
+				return err
+			}
+
+			fname, err := dt.CreateTodaySince(cutoff)
 			if err != nil {
 				return fmt.Errorf("failed to create new list: %w", err)
 			}
 
+			path := path.Join(homePath, fname)
 			fmt.Fprintf(ctx.App.Writer, "%s created ✅\n", path)
 
 			editor := os.Getenv("EDITOR")
@@ -78,17 +158,22 @@ func HandleEdit() *cli.Command {
 				}
 			}
 
-			paths, err := drtodo.GetSortedListPaths(homePath)
+			store, err := newStore()
+			if err != nil {
+				return err
+			}
+
+			names, err := drtodo.GetSortedListPaths(store)
 			if err != nil {
 				return fmt.Errorf("could not find latest list")
 			}
 
-			if len(paths) == 0 {
+			if len(names) == 0 {
 				return fmt.Errorf("no lists found in %s", homePath)
 			}
 
-			if offset >= len(paths) {
-				offset = len(paths) - 1
+			if offset >= len(names) {
+				offset = len(names) - 1
 			}
 
 			editor := os.Getenv("EDITOR")
@@ -96,15 +181,175 @@ func HandleEdit() *cli.Command {
 				return errors.New("could not open list in $EDITOR because it isn't set")
 			}
 
-			if editor != "" {
-				cmd := exec.Command(editor, paths[offset])
-				cmd.Stdout = os.Stdout
-				cmd.Stderr = os.Stderr
-				cmd.Stdin = os.Stdin
+			format, err := drtodo.FormatForExt(filepath.Ext(names[offset]))
+			if err != nil {
+				return fmt.Errorf("determining format for '%s': %w", names[offset], err)
+			}
 
-				if err := cmd.Run(); err != nil {
-					return fmt.Errorf("could not start editor '%s': %w", editor, err)
+			readList := func() (drtodo.List, error) {
+				file, err := store.Open(names[offset])
+				if err != nil {
+					return drtodo.List{}, err
 				}
+				defer file.Close()
+
+				return format.Parse(file)
+			}
+
+			before, err := readList()
+			if err != nil {
+				return fmt.Errorf("reading list before edit: %w", err)
+			}
+
+			cmd := exec.Command(editor, path.Join(homePath, names[offset]))
+			cmd.Stdout = os.Stdout
+			cmd.Stderr = os.Stderr
+			cmd.Stdin = os.Stdin
+
+			if err := cmd.Run(); err != nil {
+				return fmt.Errorf("could not start editor '%s': %w", editor, err)
+			}
+
+			after, err := readList()
+			if err != nil {
+				return fmt.Errorf("reading list after edit: %w", err)
+			}
+
+			if err := drtodo.RecordEdits(store, before, after); err != nil {
+				return fmt.Errorf("recording journal events: %w", err)
+			}
+
+			return nil
+		},
+	}
+}
+
+func HandleStale() *cli.Command {
+	var olderThan int
+
+	return &cli.Command{
+		Name:      "stale",
+		Usage:     "Lists todos that have been carried forward for a while",
+		UsageText: "dr-todo [global options] stale [--older-than N]",
+		Flags: []cli.Flag{
+			&cli.IntFlag{
+				Name:        "older-than",
+				Value:       0,
+				Usage:       "only print todos that have been carried forward more than N days",
+				Destination: &olderThan,
+			},
+		},
+		Action: func(ctx *cli.Context) error {
+			store, err := newStore()
+			if err != nil {
+				return err
+			}
+
+			list, err := drtodo.GetLatestList(store)
+			if err != nil {
+				return fmt.Errorf("getting latest list: %w", err)
+			}
+
+			for todo := range list.Todos() {
+				if todo.Completed || todo.Age <= olderThan {
+					continue
+				}
+
+				fmt.Fprintf(ctx.App.Writer, "%s (%d days old)\n", todo.String(), todo.Age)
+			}
+
+			return nil
+		},
+	}
+}
+
+func HandleLog() *cli.Command {
+	var since string
+
+	return &cli.Command{
+		Name:      "log",
+		Usage:     "Prints the event journal",
+		UsageText: "dr-todo [global options] log [--since YYYY-MM-DD]",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:        "since",
+				Value:       "",
+				Usage:       "only print events on or after YYYY-MM-DD",
+				Destination: &since,
+			},
+		},
+		Action: func(ctx *cli.Context) error {
+			store, err := newStore()
+			if err != nil {
+				return err
+			}
+
+			var cutoff time.Time
+			if since != "" {
+				cutoff, err = drtodo.ParseDate(since)
+				if err != nil {
+					return fmt.Errorf("invalid --since date '%s': %w", since, err)
+				}
+			}
+
+			for event := range drtodo.ReadJournal(store, cutoff) {
+				fmt.Fprintf(ctx.App.Writer, "%s %s %s (%s)\n", event.Timestamp.Format(time.RFC3339), event.Action, event.TodoID, event.ListID)
+			}
+
+			return nil
+		},
+	}
+}
+
+func HandleStats() *cli.Command {
+	var week bool
+	var month bool
+
+	return &cli.Command{
+		Name:      "stats",
+		Usage:     "Summarizes completion activity from the event journal",
+		UsageText: "dr-todo [global options] stats [--week|--month]",
+		Flags: []cli.Flag{
+			&cli.BoolFlag{
+				Name:        "week",
+				Value:       false,
+				Usage:       "summarize the last 7 days (default)",
+				Destination: &week,
+			},
+			&cli.BoolFlag{
+				Name:        "month",
+				Value:       false,
+				Usage:       "summarize the last 30 days",
+				Destination: &month,
+			},
+		},
+		Action: func(ctx *cli.Context) error {
+			store, err := newStore()
+			if err != nil {
+				return err
+			}
+
+			window := 7 * 24 * time.Hour
+			if month {
+				window = 30 * 24 * time.Hour
+			}
+
+			stats, err := drtodo.ComputeStats(store, time.Now().Add(-window))
+			if err != nil {
+				return fmt.Errorf("computing stats: %w", err)
+			}
+
+			fmt.Fprintf(ctx.App.Writer, "Completed: %d\n", stats.Completed)
+			fmt.Fprintf(ctx.App.Writer, "Avg time to completion: %s\n", stats.AvgTimeToComplete)
+
+			sublists := make([]string, 0, len(stats.BySublist))
+			for listID := range stats.BySublist {
+				sublists = append(sublists, listID)
+			}
+			sort.Strings(sublists)
+
+			for _, listID := range sublists {
+				fmt.Fprintf(ctx.App.Writer, "  %s: %d\n", listID, stats.BySublist[listID])
 			}
 
 			return nil
@@ -135,6 +380,18 @@ func Run() error {
 				Usage:       "sets home directory dr-todo should parse and save todos to",
 				Destination: &homePath,
 			},
+			&cli.StringFlag{
+				Name:        "store",
+				Value:       "disk",
+				Usage:       "sets the storage backend dr-todo should use: 'disk' or 'git'",
+				Destination: &storeKind,
+			},
+			&cli.StringFlag{
+				Name:        "format",
+				Value:       "md",
+				Usage:       "sets the list format to use when there's no previous list to carry forward: 'md', 'org', 'taskpaper', or 'json'",
+				Destination: &formatKind,
+			},
 		},
 		Before: func(ctx *cli.Context) error {
 			if homePath == "" {
@@ -153,6 +410,9 @@ func Run() error {
 		Commands: []*cli.Command{
 			HandleNew(),
 			HandleEdit(),
+			HandleStale(),
+			HandleLog(),
+			HandleStats(),
 		},
 	}
 
@@ -5,8 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
-	"math"
-	"os"
+	"iter"
 	"path/filepath"
 	"slices"
 	"strings"
@@ -27,12 +26,17 @@ func FormatDate(date time.Time) string {
 	return date.Format(dateLayout)
 }
 
-var todoID = math.MaxInt32
-
 type Todo struct {
+	ID        string
 	ListID    string
 	Name      string
 	Completed bool
+
+	// Age and FirstSeen are only populated when a Todo is produced by
+	// List.Todos() against a List that was loaded with an Index; they
+	// aren't part of a todo's on-disk representation.
+	Age       int
+	FirstSeen time.Time
 }
 
 func (t Todo) String() string {
@@ -47,6 +51,30 @@ func (t Todo) String() string {
 type List struct {
 	Name     string
 	Sublists *ordmap.OrdMap[string, []Todo]
+
+	// index, when set, is consulted by Todos() to fill in each Todo's
+	// Age and FirstSeen.
+	index Index
+}
+
+// Todos flattens every sublist's todos into a single sequence. If the List
+// was loaded with an Index attached, each Todo's Age and FirstSeen are
+// filled in from its carry-forward history.
+func (l List) Todos() iter.Seq[Todo] {
+	return func(yield func(Todo) bool) {
+		for _, todos := range l.Sublists.EntryIter() {
+			for _, todo := range todos {
+				if firstSeen, ok := l.index.firstSeen(todo.ID); ok {
+					todo.FirstSeen = firstSeen
+					todo.Age = int(time.Since(firstSeen).Hours() / 24)
+				}
+
+				if !yield(todo) {
+					return
+				}
+			}
+		}
+	}
 }
 
 func getHeaderWithDepth(input string) (string, int) {
@@ -63,11 +91,6 @@ func getHeaderWithDepth(input string) (string, int) {
 
 const listSep = ":@>"
 
-type listName struct {
-	name  string
-	depth int
-}
-
 func parseList(reader io.Reader) (List, error) {
 	r := bufio.NewReader(reader)
 	listStack := make([]listName, 0, 10)
@@ -89,33 +112,12 @@ func parseList(reader io.Reader) (List, error) {
 		}
 
 		if line[0] == '#' {
-			depth := 0
-			if len(listStack) > 0 {
-				depth = listStack[len(listStack)-1].depth
-			}
-			name, d := getHeaderWithDepth(rawLine)
-			ln := listName{name: name, depth: d}
-			switch {
-			case d > depth:
-				listStack = append(listStack, ln)
-			case d == depth:
-				listStack[len(listStack)-1] = ln
-			case d < depth:
-				for len(listStack) > 0 && listStack[len(listStack)-1].depth >= d {
-					listStack = listStack[:len(listStack)-1]
-				}
-				listStack = append(listStack, ln)
-			}
-
+			name, depth := getHeaderWithDepth(rawLine)
+			listStack = pushHeading(listStack, name, depth)
 			continue
 		}
 
-		listNames := make([]string, len(listStack))
-		for idx, ln := range listStack {
-			listNames[idx] = ln.name
-		}
-
-		listID := strings.Join(listNames, listSep)
+		listID := listIDFor(listStack)
 		todo, err := ParseTodo(listID, rawLine)
 		if err != nil {
 			return List{}, fmt.Errorf("parsing todo: %w", err)
@@ -154,12 +156,23 @@ func (l List) Dump(w io.Writer, omitCompleted bool) error {
 }
 
 type DrTodo struct {
-	home string
+	store  Store
+	format Format
 }
 
+// New returns a DrTodo backed by a DiskStore rooted at homePath, writing
+// new lists as markdown.
 func New(homePath string) DrTodo {
+	return NewWithStore(NewDiskStore(homePath), markdownFormat{})
+}
+
+// NewWithStore returns a DrTodo backed by an arbitrary Store. format is
+// only used when there's no previous list to carry forward; otherwise the
+// previous list's own format is preserved.
+func NewWithStore(store Store, format Format) DrTodo {
 	return DrTodo{
-		home: homePath,
+		store:  store,
+		format: format,
 	}
 }
 
@@ -180,11 +193,7 @@ func ParseTodo(listID, input string) (Todo, error) {
 		return Todo{}, fmt.Errorf("invalid checkbox string '%s'", checkbox)
 	}
 
-	return Todo{
-		ListID:    listID,
-		Name:      strings.Trim(parts[1], " \n"),
-		Completed: checked,
-	}, nil
+	return newTodo(listID, strings.Trim(parts[1], " \n"), checked), nil
 }
 
 type listResult struct {
@@ -203,100 +212,116 @@ func ParseList(name string, reader io.Reader) (List, error) {
 	return res, nil
 }
 
-func GetLatestList(path string) (List, error) {
-	paths, err := GetSortedListPaths(path)
+// latestListAndFormat returns the latest list's store name, the Format its
+// extension maps to, and its parsed contents.
+func latestListAndFormat(store Store) (string, Format, List, error) {
+	names, err := GetSortedListPaths(store)
 	if err != nil {
-		return List{}, fmt.Errorf("getting sorted paths: %w", err)
+		return "", nil, List{}, fmt.Errorf("getting sorted paths: %w", err)
 	}
 
-	if len(paths) == 0 {
-		return List{}, ErrListNotFound
+	if len(names) == 0 {
+		return "", nil, List{}, ErrListNotFound
 	}
 
-	file, err := os.Open(paths[0])
+	format, err := FormatForExt(filepath.Ext(names[0]))
 	if err != nil {
-		return List{}, fmt.Errorf("opening latest file: %w", err)
+		return "", nil, List{}, fmt.Errorf("determining format: %w", err)
+	}
+
+	file, err := store.Open(names[0])
+	if err != nil {
+		return "", nil, List{}, fmt.Errorf("opening latest file: %w", err)
 	}
 	defer file.Close()
 
-	listName := strings.TrimSuffix(filepath.Base(file.Name()), filepath.Ext(file.Name()))
-	list, err := ParseList(listName, file)
+	list, err := format.Parse(file)
 	if err != nil {
-		return List{}, fmt.Errorf("parsing list: %w", err)
+		return "", nil, List{}, fmt.Errorf("parsing list: %w", err)
 	}
 
-	return list, nil
+	list.Name = strings.TrimSuffix(names[0], filepath.Ext(names[0]))
+
+	idx, err := loadIndex(store)
+	if err != nil {
+		return "", nil, List{}, fmt.Errorf("loading index: %w", err)
+	}
+	list.index = idx
+
+	return names[0], format, list, nil
+}
+
+// GetLatestList returns the most recently dated list found in store.
+func GetLatestList(store Store) (List, error) {
+	_, _, list, err := latestListAndFormat(store)
+	return list, err
 }
 
-func GetSortedListPaths(path string) ([]string, error) {
-	entries, err := os.ReadDir(path)
+// GetSortedListPaths returns the names of every list in store whose
+// extension maps to a registered Format and whose remaining name parses as
+// a date, sorted newest first. Entries are not skipped: if any entry's
+// extension has no registered Format or its name doesn't parse as a date,
+// it returns an error instead.
+func GetSortedListPaths(store Store) ([]string, error) {
+	entries, err := store.List()
 	if err != nil {
-		return nil, fmt.Errorf("reading dir: %w", err)
+		return nil, fmt.Errorf("listing store entries: %w", err)
 	}
 
 	if len(entries) == 0 {
 		return nil, nil
 	}
 
-	dates := []time.Time{}
+	type dated struct {
+		name string
+		date time.Time
+	}
+
+	items := make([]dated, 0, len(entries))
 	for _, entry := range entries {
-		parts := strings.Split(entry.Name(), ".")
-		if len(parts) != 2 {
-			return nil, errors.New("file names can only contain a single '.'")
+		ext := filepath.Ext(entry)
+		if _, err := FormatForExt(ext); err != nil {
+			return nil, fmt.Errorf("determining format for '%s': %w", entry, err)
 		}
 
-		date, err := ParseDate(parts[0])
+		date, err := ParseDate(strings.TrimSuffix(entry, ext))
 		if err != nil {
-			return nil, fmt.Errorf("parsing date: %w", err)
+			return nil, fmt.Errorf("parsing date from '%s': %w", entry, err)
 		}
 
-		dates = append(dates, date)
+		items = append(items, dated{name: entry, date: date})
 	}
 
-	slices.SortFunc(dates, func(a time.Time, b time.Time) int {
-		return b.Compare(a)
+	slices.SortFunc(items, func(a, b dated) int {
+		return b.date.Compare(a.date)
 	})
 
-	paths := make([]string, len(dates))
-	for idx, date := range dates {
-		paths[idx] = filepath.Join(path, FormatDate(date)+".md")
+	names := make([]string, len(items))
+	for idx, item := range items {
+		names[idx] = item.name
 	}
 
-	return paths, nil
+	return names, nil
 }
 
+// CreateToday creates today's list, carrying forward only the single most
+// recent previous list's unfinished todos. See CreateTodaySince to merge
+// every list back to a cutoff in one go.
 func (dt DrTodo) CreateToday() (string, error) {
-
-	today := FormatDate(time.Now())
-	fname := today + ".md"
-	path := filepath.Join(dt.home, fname)
-
-	_, err := os.Stat(path)
-	if err == nil {
-		return "", fmt.Errorf("file '%s' already exists", fname)
-	}
-
-	if !errors.Is(err, os.ErrNotExist) {
-		return "", fmt.Errorf("checking if file exists: %w", err)
-	}
-
-	latest, err := GetLatestList(dt.home)
+	names, err := GetSortedListPaths(dt.store)
 	if err != nil {
-		if err != ErrListNotFound {
-			return "", fmt.Errorf("getting previous list: %w", err)
-		}
+		return "", fmt.Errorf("getting sorted paths: %w", err)
 	}
 
-	latest.Name = fmt.Sprintf("TODO %s", today)
-	file, err := os.Create(path)
-	if err != nil {
-		return "", fmt.Errorf("creating new todo file: %w", err)
+	if len(names) == 0 {
+		return dt.CreateTodaySince(time.Now())
 	}
-	defer file.Close()
 
-	if err := latest.Dump(file, true); err != nil {
-		return "", fmt.Errorf("dumping previous list: %w", err)
+	ext := filepath.Ext(names[0])
+	cutoff, err := ParseDate(strings.TrimSuffix(names[0], ext))
+	if err != nil {
+		return "", fmt.Errorf("parsing date from '%s': %w", names[0], err)
 	}
 
-	return path, nil
+	return dt.CreateTodaySince(cutoff)
 }
@@ -2,9 +2,10 @@ package drtodo_test
 
 import (
 	"bytes"
+	"encoding/json"
 	"errors"
-	"os"
-	"path/filepath"
+	"io"
+	"strings"
 	"testing"
 	"time"
 
@@ -60,7 +61,9 @@ func Test_ParseTodo(t *testing.T) {
 			}
 
 			assert.NoError(t, err)
-			assert.Equal(t, c.expected, td)
+			assert.Equal(t, c.expected.Name, td.Name)
+			assert.Equal(t, c.expected.Completed, td.Completed)
+			assert.NotEmpty(t, td.ID)
 		})
 	}
 }
@@ -149,28 +152,289 @@ $ Stretch Goals
 	}
 }
 
+func Test_OrgRoundTrip(t *testing.T) {
+	input := `* Project
+** TODO Uncategorized
+** DONE Completed already
+
+* Stretch Goals
+** TODO Do a stretch
+`
+	expected := `* Project
+** TODO Uncategorized
+
+* Stretch Goals
+** TODO Do a stretch
+`
+
+	format, err := drtodo.FormatForExt("org")
+	require.NoError(t, err)
+
+	list, err := format.Parse(strings.NewReader(input))
+	require.NoError(t, err)
+
+	out := bytes.NewBuffer(nil)
+	require.NoError(t, format.Dump(out, list, drtodo.DumpOptions{OmitCompleted: true}))
+	assert.Equal(t, expected, out.String())
+}
+
+func Test_TaskpaperRoundTrip(t *testing.T) {
+	cases := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "basic",
+			input:    "Project:\n\t- Uncategorized\n\t- Completed already @done\n\nStretch Goals:\n\t- Do a stretch\n",
+			expected: "Project:\n\t- Uncategorized\n\nStretch Goals:\n\t- Do a stretch\n",
+		},
+		{
+			name:     "@done as a substring doesn't mark completion",
+			input:    "Project:\n\t- Email admin@done.com about outage\n",
+			expected: "Project:\n\t- Email admin@done.com about outage\n",
+		},
+	}
+
+	format, err := drtodo.FormatForExt("taskpaper")
+	require.NoError(t, err)
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			list, err := format.Parse(strings.NewReader(c.input))
+			require.NoError(t, err)
+
+			out := bytes.NewBuffer(nil)
+			require.NoError(t, format.Dump(out, list, drtodo.DumpOptions{OmitCompleted: true}))
+			assert.Equal(t, c.expected, out.String())
+		})
+	}
+}
+
+func Test_JSONRoundTrip(t *testing.T) {
+	input := `[
+		{"id": "Project", "todos": [
+			{"name": "Uncategorized", "completed": false},
+			{"name": "Completed already", "completed": true}
+		]},
+		{"id": "Stretch Goals", "todos": [
+			{"name": "Do a stretch", "completed": false}
+		]}
+	]`
+
+	format, err := drtodo.FormatForExt("json")
+	require.NoError(t, err)
+
+	list, err := format.Parse(strings.NewReader(input))
+	require.NoError(t, err)
+
+	out := bytes.NewBuffer(nil)
+	require.NoError(t, format.Dump(out, list, drtodo.DumpOptions{OmitCompleted: true}))
+
+	var raw []struct {
+		ID    string `json:"id"`
+		Todos []struct {
+			Name      string `json:"name"`
+			Completed bool   `json:"completed"`
+		} `json:"todos"`
+	}
+	require.NoError(t, json.Unmarshal(out.Bytes(), &raw))
+
+	require.Len(t, raw, 2)
+	assert.Equal(t, "Project", raw[0].ID)
+	require.Len(t, raw[0].Todos, 1)
+	assert.Equal(t, "Uncategorized", raw[0].Todos[0].Name)
+	assert.Equal(t, "Stretch Goals", raw[1].ID)
+	require.Len(t, raw[1].Todos, 1)
+	assert.Equal(t, "Do a stretch", raw[1].Todos[0].Name)
+}
+
 func Test_GetLatestList(t *testing.T) {
 	// SETUP
-	dir := t.TempDir()
+	store := drtodo.NewMemStore()
 	now := time.Now()
 
 	today := drtodo.FormatDate(now)
-	file, err := os.Create(filepath.Join(dir, today+".md"))
-	assert.NoError(t, err)
-	defer file.Close()
+	for _, date := range []time.Time{now, now.Add(-24 * time.Hour), now.Add(-48 * time.Hour)} {
+		file, err := store.Create(drtodo.FormatDate(date) + ".md")
+		assert.NoError(t, err)
+		assert.NoError(t, file.Close())
+	}
 
-	file, err = os.Create(filepath.Join(dir, drtodo.FormatDate(now.Add(-24*time.Hour))+".md"))
+	// RUN
+	list, err := drtodo.GetLatestList(store)
 	assert.NoError(t, err)
+
+	// ASSERT
+	assert.Equal(t, today, list.Name)
+}
+
+func Test_CreateTodaySince(t *testing.T) {
+	// SETUP
+	store := drtodo.NewMemStore()
+	now := time.Now()
+
+	day := func(offset int) string {
+		return drtodo.FormatDate(now.Add(time.Duration(offset) * 24 * time.Hour))
+	}
+
+	write := func(offset int, content string) {
+		file, err := store.Create(day(offset) + ".md")
+		require.NoError(t, err)
+		_, err = file.Write([]byte(content))
+		require.NoError(t, err)
+		require.NoError(t, file.Close())
+	}
+
+	write(-3, "# Project\n- [x] Done already\n")
+	write(-2, "# Project\n- [] Carry me\n- [x] Already finished\n")
+	write(-1, "# Project\n- [] Carry me\n- [] Also carry me\n")
+
+	format, err := drtodo.FormatForExt("md")
+	require.NoError(t, err)
+	dt := drtodo.NewWithStore(store, format)
+
+	// RUN
+	fname, err := dt.CreateTodaySince(now.Add(-2 * 24 * time.Hour))
+	require.NoError(t, err)
+
+	// ASSERT
+	assert.Equal(t, day(0)+".md", fname)
+
+	file, err := store.Open(fname)
+	require.NoError(t, err)
 	defer file.Close()
 
-	file, err = os.Create(filepath.Join(dir, drtodo.FormatDate(now.Add(-48*time.Hour))+".md"))
-	assert.NoError(t, err)
+	list, err := format.Parse(file)
+	require.NoError(t, err)
+
+	names := make(map[string]bool)
+	count := 0
+	for todo := range list.Todos() {
+		names[todo.Name] = true
+		count++
+	}
+
+	assert.Equal(t, 2, count, "Carry me should only be merged in once despite appearing on two days")
+	assert.True(t, names["Carry me"])
+	assert.True(t, names["Also carry me"])
+	assert.False(t, names["Done already"])
+	assert.False(t, names["Already finished"])
+}
+
+func Test_CreateTodaySince_ResolvesNewestState(t *testing.T) {
+	// SETUP: "Buy milk" is unfinished on day -2, then finished on day -1.
+	// Merging from day -2 through today should drop it, not resurrect it
+	// as unfinished just because an older occurrence was still open.
+	store := drtodo.NewMemStore()
+	now := time.Now()
+
+	day := func(offset int) string {
+		return drtodo.FormatDate(now.Add(time.Duration(offset) * 24 * time.Hour))
+	}
+
+	write := func(offset int, content string) {
+		file, err := store.Create(day(offset) + ".md")
+		require.NoError(t, err)
+		_, err = file.Write([]byte(content))
+		require.NoError(t, err)
+		require.NoError(t, file.Close())
+	}
+
+	write(-2, "# Project\n- [] Buy milk\n")
+	write(-1, "# Project\n- [x] Buy milk\n")
+
+	format, err := drtodo.FormatForExt("md")
+	require.NoError(t, err)
+	dt := drtodo.NewWithStore(store, format)
+
+	// RUN
+	fname, err := dt.CreateTodaySince(now.Add(-2 * 24 * time.Hour))
+	require.NoError(t, err)
+
+	// ASSERT
+	file, err := store.Open(fname)
+	require.NoError(t, err)
 	defer file.Close()
 
+	list, err := format.Parse(file)
+	require.NoError(t, err)
+
+	count := 0
+	for range list.Todos() {
+		count++
+	}
+	assert.Equal(t, 0, count, "a todo completed on a more recent day must not be resurrected from an older, still-open occurrence")
+
+	completions := 0
+	for event := range drtodo.ReadJournal(store, time.Time{}) {
+		if event.Action == drtodo.ActionCompleted {
+			completions++
+		}
+	}
+	assert.Equal(t, 1, completions, "a todo resolved as completed must only be journaled once")
+}
+
+func Test_CreateTodaySince_Journal(t *testing.T) {
+	// SETUP
+	store := drtodo.NewMemStore()
+	now := time.Now()
+
+	write := func(offset int, content string) {
+		file, err := store.Create(drtodo.FormatDate(now.Add(time.Duration(offset)*24*time.Hour)) + ".md")
+		require.NoError(t, err)
+		_, err = file.Write([]byte(content))
+		require.NoError(t, err)
+		require.NoError(t, file.Close())
+	}
+
+	write(-1, "# Project\n- [] Carry me\n- [x] Finish me\n")
+
+	format, err := drtodo.FormatForExt("md")
+	require.NoError(t, err)
+	dt := drtodo.NewWithStore(store, format)
+
 	// RUN
-	list, err := drtodo.GetLatestList(dir)
-	assert.NoError(t, err)
+	_, err = dt.CreateTodaySince(now.Add(-1 * 24 * time.Hour))
+	require.NoError(t, err)
 
 	// ASSERT
-	assert.Equal(t, today, list.Name)
+	actions := make(map[drtodo.Action]int)
+	for event := range drtodo.ReadJournal(store, time.Time{}) {
+		actions[event.Action]++
+	}
+
+	assert.Equal(t, 1, actions[drtodo.ActionCreated], "unfinished todo should be journaled as created")
+	assert.Equal(t, 1, actions[drtodo.ActionCompleted], "finished todo should be journaled as completed")
+}
+
+func Test_GitStore(t *testing.T) {
+	// SETUP: a real directory is required since GitStore shells out to
+	// git, so this can't use MemStore like the rest of the suite.
+	store, err := drtodo.NewGitStore(t.TempDir())
+	require.NoError(t, err)
+
+	// RUN
+	file, err := store.Create("2024-01-01.md")
+	require.NoError(t, err)
+	_, err = file.Write([]byte("# Project\n- [] Test\n"))
+	require.NoError(t, err)
+	require.NoError(t, file.Close())
+
+	appended, err := store.Append("2024-01-01.md")
+	require.NoError(t, err)
+	_, err = appended.Write([]byte("- [x] More\n"))
+	require.NoError(t, err)
+	require.NoError(t, appended.Close())
+
+	// ASSERT: both writes succeeded (i.e. committed) without a global git
+	// identity configured, and the appended content landed in the file.
+	reader, err := store.Open("2024-01-01.md")
+	require.NoError(t, err)
+	defer reader.Close()
+
+	data, err := io.ReadAll(reader)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "- [] Test")
+	assert.Contains(t, string(data), "- [x] More")
 }
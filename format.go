@@ -0,0 +1,87 @@
+package drtodo
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// DumpOptions controls how a Format serializes a List back out.
+type DumpOptions struct {
+	// OmitCompleted skips already-finished todos, used when carrying a
+	// list forward to a new day.
+	OmitCompleted bool
+}
+
+// Format parses and serializes a List to and from a particular on-disk
+// representation (markdown, org-mode, TaskPaper, JSON, ...).
+type Format interface {
+	// Ext is the file extension (without a leading '.') this format is
+	// registered under.
+	Ext() string
+	Parse(r io.Reader) (List, error)
+	Dump(w io.Writer, list List, opts DumpOptions) error
+}
+
+var formats = map[string]Format{}
+
+// RegisterFormat makes f available to FormatForExt under f.Ext(). It's
+// meant to be called from each format implementation's init.
+func RegisterFormat(f Format) {
+	formats[f.Ext()] = f
+}
+
+// FormatForExt looks up the Format registered for ext, which may be given
+// with or without its leading '.'.
+func FormatForExt(ext string) (Format, error) {
+	ext = strings.TrimPrefix(ext, ".")
+	f, ok := formats[ext]
+	if !ok {
+		return nil, fmt.Errorf("no format registered for extension '%s'", ext)
+	}
+
+	return f, nil
+}
+
+// listName tracks a heading's display name alongside the depth it was
+// found at, so formats with nested headings can reconstruct a list's
+// sublist hierarchy.
+type listName struct {
+	name  string
+	depth int
+}
+
+// pushHeading folds a newly parsed heading into stack, honoring its depth
+// relative to the current top of stack: deeper headings nest, equal-depth
+// headings replace, and shallower headings pop back up before pushing.
+func pushHeading(stack []listName, name string, depth int) []listName {
+	ln := listName{name: name, depth: depth}
+	if len(stack) == 0 {
+		return append(stack, ln)
+	}
+
+	cur := stack[len(stack)-1].depth
+	switch {
+	case depth > cur:
+		return append(stack, ln)
+	case depth == cur:
+		stack[len(stack)-1] = ln
+		return stack
+	default:
+		for len(stack) > 0 && stack[len(stack)-1].depth >= depth {
+			stack = stack[:len(stack)-1]
+		}
+		return append(stack, ln)
+	}
+}
+
+// listIDFor joins the names in stack into the listSep-delimited ID used to
+// key List.Sublists.
+func listIDFor(stack []listName) string {
+	names := make([]string, len(stack))
+	for idx, ln := range stack {
+		names[idx] = ln.name
+	}
+
+	return strings.Join(names, listSep)
+}
@@ -0,0 +1,69 @@
+package drtodo
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/eriktate/go-ordmap"
+)
+
+func init() { RegisterFormat(jsonFormat{}) }
+
+// jsonFormat reads and writes a list as a JSON array of sublists, each
+// holding its listID and todos, preserving sublist order.
+type jsonFormat struct{}
+
+func (jsonFormat) Ext() string { return "json" }
+
+type jsonTodo struct {
+	Name      string `json:"name"`
+	Completed bool   `json:"completed"`
+}
+
+type jsonSublist struct {
+	ID    string     `json:"id"`
+	Todos []jsonTodo `json:"todos"`
+}
+
+func (jsonFormat) Parse(r io.Reader) (List, error) {
+	var raw []jsonSublist
+	if err := json.NewDecoder(r).Decode(&raw); err != nil {
+		return List{}, fmt.Errorf("decoding json list: %w", err)
+	}
+
+	sublists := ordmap.NewUnsafe[string, []Todo](len(raw))
+	for _, sl := range raw {
+		todos := make([]Todo, len(sl.Todos))
+		for idx, t := range sl.Todos {
+			todos[idx] = newTodo(sl.ID, t.Name, t.Completed)
+		}
+		sublists.Set(sl.ID, todos)
+	}
+
+	return List{Sublists: sublists}, nil
+}
+
+func (jsonFormat) Dump(w io.Writer, list List, opts DumpOptions) error {
+	raw := make([]jsonSublist, 0)
+	for listID, todos := range list.Sublists.EntryIter() {
+		jTodos := make([]jsonTodo, 0, len(todos))
+		for _, todo := range todos {
+			if opts.OmitCompleted && todo.Completed {
+				continue
+			}
+
+			jTodos = append(jTodos, jsonTodo{Name: todo.Name, Completed: todo.Completed})
+		}
+
+		raw = append(raw, jsonSublist{ID: listID, Todos: jTodos})
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(raw); err != nil {
+		return fmt.Errorf("encoding json list: %w", err)
+	}
+
+	return nil
+}
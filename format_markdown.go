@@ -0,0 +1,19 @@
+package drtodo
+
+import "io"
+
+func init() { RegisterFormat(markdownFormat{}) }
+
+// markdownFormat is dr-todo's original on-disk representation: '#'
+// headings nest sublists, and '- [ ]'/'- [x]' lines are todos.
+type markdownFormat struct{}
+
+func (markdownFormat) Ext() string { return "md" }
+
+func (markdownFormat) Parse(r io.Reader) (List, error) {
+	return parseList(r)
+}
+
+func (markdownFormat) Dump(w io.Writer, list List, opts DumpOptions) error {
+	return list.Dump(w, opts.OmitCompleted)
+}
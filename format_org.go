@@ -0,0 +1,96 @@
+package drtodo
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/eriktate/go-ordmap"
+)
+
+func init() { RegisterFormat(orgFormat{}) }
+
+// orgFormat reads and writes a subset of Emacs org-mode: '*' headings nest
+// sublists the same way markdown's '#' headings do, and a heading prefixed
+// with the TODO or DONE cookie is a todo rather than a sublist.
+type orgFormat struct{}
+
+func (orgFormat) Ext() string { return "org" }
+
+func (orgFormat) Parse(r io.Reader) (List, error) {
+	scanner := bufio.NewScanner(r)
+	listStack := make([]listName, 0, 10)
+	sublists := ordmap.NewUnsafe[string, []Todo](10)
+
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		depth := 0
+		for depth < len(line) && line[depth] == '*' {
+			depth++
+		}
+
+		if depth == 0 {
+			return List{}, fmt.Errorf("expected a heading, got '%s'", line)
+		}
+
+		rest := strings.TrimSpace(line[depth:])
+		switch {
+		case strings.HasPrefix(rest, "TODO "):
+			listID := listIDFor(listStack)
+			todo := newTodo(listID, strings.TrimPrefix(rest, "TODO "), false)
+			todos, _ := sublists.Get(listID)
+			sublists.Set(listID, append(todos, todo))
+		case strings.HasPrefix(rest, "DONE "):
+			listID := listIDFor(listStack)
+			todo := newTodo(listID, strings.TrimPrefix(rest, "DONE "), true)
+			todos, _ := sublists.Get(listID)
+			sublists.Set(listID, append(todos, todo))
+		default:
+			listStack = pushHeading(listStack, rest, depth)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return List{}, fmt.Errorf("reading list: %w", err)
+	}
+
+	return List{Sublists: sublists}, nil
+}
+
+func (orgFormat) Dump(w io.Writer, list List, opts DumpOptions) error {
+	firstLine := true
+	for listID, todos := range list.Sublists.EntryIter() {
+		parts := strings.Split(listID, listSep)
+		if !firstLine {
+			fmt.Fprint(w, "\n")
+		}
+		firstLine = false
+
+		stars := strings.Repeat("*", len(parts))
+		if _, err := fmt.Fprintf(w, "%s %s\n", stars, parts[len(parts)-1]); err != nil {
+			return fmt.Errorf("writing heading: %w", err)
+		}
+
+		for _, todo := range todos {
+			if opts.OmitCompleted && todo.Completed {
+				continue
+			}
+
+			cookie := "TODO"
+			if todo.Completed {
+				cookie = "DONE"
+			}
+
+			if _, err := fmt.Fprintf(w, "%s %s %s\n", strings.Repeat("*", len(parts)+1), cookie, todo.Name); err != nil {
+				return fmt.Errorf("writing todo: %w", err)
+			}
+		}
+	}
+
+	return nil
+}
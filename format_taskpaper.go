@@ -0,0 +1,111 @@
+package drtodo
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/eriktate/go-ordmap"
+)
+
+func init() { RegisterFormat(taskpaperFormat{}) }
+
+// taskpaperFormat reads and writes TaskPaper: tab-indented "Project:"
+// lines nest sublists, and "- item" lines are todos, tagged "@done" when
+// completed.
+type taskpaperFormat struct{}
+
+func (taskpaperFormat) Ext() string { return "taskpaper" }
+
+func (taskpaperFormat) Parse(r io.Reader) (List, error) {
+	scanner := bufio.NewScanner(r)
+	listStack := make([]listName, 0, 10)
+	sublists := ordmap.NewUnsafe[string, []Todo](10)
+
+	for scanner.Scan() {
+		raw := scanner.Text()
+		if strings.TrimSpace(raw) == "" {
+			continue
+		}
+
+		depth := 0
+		for depth < len(raw) && raw[depth] == '\t' {
+			depth++
+		}
+		line := raw[depth:]
+
+		switch {
+		case strings.HasSuffix(line, ":"):
+			listStack = pushHeading(listStack, strings.TrimSuffix(line, ":"), depth)
+		case strings.HasPrefix(line, "- "):
+			name, completed := stripDoneTag(strings.TrimPrefix(line, "- "))
+
+			listID := listIDFor(listStack)
+			todo := newTodo(listID, name, completed)
+			todos, _ := sublists.Get(listID)
+			sublists.Set(listID, append(todos, todo))
+		default:
+			return List{}, fmt.Errorf("unrecognized taskpaper line '%s'", raw)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return List{}, fmt.Errorf("reading list: %w", err)
+	}
+
+	return List{Sublists: sublists}, nil
+}
+
+func (taskpaperFormat) Dump(w io.Writer, list List, opts DumpOptions) error {
+	firstLine := true
+	for listID, todos := range list.Sublists.EntryIter() {
+		parts := strings.Split(listID, listSep)
+		if !firstLine {
+			fmt.Fprint(w, "\n")
+		}
+		firstLine = false
+
+		depth := len(parts) - 1
+		if _, err := fmt.Fprintf(w, "%s%s:\n", strings.Repeat("\t", depth), parts[len(parts)-1]); err != nil {
+			return fmt.Errorf("writing project: %w", err)
+		}
+
+		for _, todo := range todos {
+			if opts.OmitCompleted && todo.Completed {
+				continue
+			}
+
+			line := fmt.Sprintf("%s- %s", strings.Repeat("\t", depth+1), todo.Name)
+			if todo.Completed {
+				line += " @done"
+			}
+
+			if _, err := fmt.Fprintln(w, line); err != nil {
+				return fmt.Errorf("writing todo: %w", err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// stripDoneTag splits name into its text and completion state, recognizing
+// "@done" only as a whitespace-delimited token so it doesn't false-positive
+// on a todo whose name merely contains that substring (e.g. an email
+// address).
+func stripDoneTag(name string) (string, bool) {
+	fields := strings.Fields(name)
+	completed := false
+
+	kept := fields[:0]
+	for _, field := range fields {
+		if field == "@done" {
+			completed = true
+			continue
+		}
+		kept = append(kept, field)
+	}
+
+	return strings.Join(kept, " "), completed
+}
@@ -0,0 +1,119 @@
+package drtodo
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+// indexName is the sidecar file every Store tracks carry-forward history
+// in, keyed by a todo's stable ID.
+const indexName = ".drtodo/index"
+
+// IndexEntry records a single occurrence of a todo ID in a dated list, and
+// when (if ever) it was completed there.
+type IndexEntry struct {
+	ListName    string
+	CompletedAt *time.Time
+}
+
+// Index maps a todo's stable ID to every list it has been recorded
+// against, oldest first.
+type Index map[string][]IndexEntry
+
+// firstSeen returns the earliest list name a todo ID has been recorded
+// against, parsed as a date.
+func (idx Index) firstSeen(id string) (time.Time, bool) {
+	entries := idx[id]
+	if len(entries) == 0 {
+		return time.Time{}, false
+	}
+
+	earliest := entries[0].ListName
+	for _, entry := range entries[1:] {
+		if entry.ListName < earliest {
+			earliest = entry.ListName
+		}
+	}
+
+	date, err := ParseDate(earliest)
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	return date, true
+}
+
+// loadIndex reads the sidecar index out of store, returning an empty Index
+// if it doesn't exist yet.
+func loadIndex(store Store) (Index, error) {
+	idx := make(Index)
+
+	file, err := store.Open(indexName)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return idx, nil
+		}
+
+		return nil, fmt.Errorf("opening index: %w", err)
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			continue
+		}
+
+		parts := strings.Split(line, "\t")
+		if len(parts) != 3 {
+			return nil, fmt.Errorf("malformed index line '%s'", line)
+		}
+
+		var completedAt *time.Time
+		if parts[2] != "-" {
+			t, err := time.Parse(time.RFC3339, parts[2])
+			if err != nil {
+				return nil, fmt.Errorf("parsing completedAt in index: %w", err)
+			}
+			completedAt = &t
+		}
+
+		idx[parts[0]] = append(idx[parts[0]], IndexEntry{ListName: parts[1], CompletedAt: completedAt})
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading index: %w", err)
+	}
+
+	return idx, nil
+}
+
+// saveIndex writes idx back out to store's sidecar index file, one
+// tab-separated "id\tlistName\tcompletedAt" record per line.
+func saveIndex(store Store, idx Index) error {
+	file, err := store.Create(indexName)
+	if err != nil {
+		return fmt.Errorf("creating index: %w", err)
+	}
+	defer file.Close()
+
+	for id, entries := range idx {
+		for _, entry := range entries {
+			completedAt := "-"
+			if entry.CompletedAt != nil {
+				completedAt = entry.CompletedAt.Format(time.RFC3339)
+			}
+
+			if _, err := fmt.Fprintf(file, "%s\t%s\t%s\n", id, entry.ListName, completedAt); err != nil {
+				return fmt.Errorf("writing index entry: %w", err)
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,250 @@
+package drtodo
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"iter"
+	"strings"
+	"time"
+)
+
+// journalName is the sidecar file every Store appends structured events to
+// as lists are created and edited.
+const journalName = ".drtodo/journal"
+
+// Action names a single state transition a todo went through, as recorded
+// in the journal.
+type Action string
+
+const (
+	ActionCreated   Action = "created"
+	ActionCompleted Action = "completed"
+	ActionCarried   Action = "carried"
+	ActionRenamed   Action = "renamed"
+	ActionDeleted   Action = "deleted"
+)
+
+// Event is a single journal record: a todo identified by TodoID underwent
+// Action within ListID at Timestamp. From and To are only set for
+// ActionRenamed.
+type Event struct {
+	Timestamp time.Time
+	ListID    string
+	TodoID    string
+	Action    Action
+	From      string
+	To        string
+}
+
+// writeEvent appends ev to w as a recfile-style record: one "Key: value"
+// line per field, blank-line terminated.
+func writeEvent(w io.Writer, ev Event) error {
+	if _, err := fmt.Fprintf(w, "Timestamp: %s\n", ev.Timestamp.Format(time.RFC3339)); err != nil {
+		return fmt.Errorf("writing Timestamp: %w", err)
+	}
+
+	if _, err := fmt.Fprintf(w, "ListID: %s\n", ev.ListID); err != nil {
+		return fmt.Errorf("writing ListID: %w", err)
+	}
+
+	if _, err := fmt.Fprintf(w, "TodoID: %s\n", ev.TodoID); err != nil {
+		return fmt.Errorf("writing TodoID: %w", err)
+	}
+
+	if _, err := fmt.Fprintf(w, "Action: %s\n", ev.Action); err != nil {
+		return fmt.Errorf("writing Action: %w", err)
+	}
+
+	if ev.From != "" {
+		if _, err := fmt.Fprintf(w, "From: %s\n", ev.From); err != nil {
+			return fmt.Errorf("writing From: %w", err)
+		}
+	}
+
+	if ev.To != "" {
+		if _, err := fmt.Fprintf(w, "To: %s\n", ev.To); err != nil {
+			return fmt.Errorf("writing To: %w", err)
+		}
+	}
+
+	if _, err := fmt.Fprint(w, "\n"); err != nil {
+		return fmt.Errorf("writing record separator: %w", err)
+	}
+
+	return nil
+}
+
+// appendEvents appends events to store's journal, a no-op if events is
+// empty.
+func appendEvents(store Store, events []Event) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	file, err := store.Append(journalName)
+	if err != nil {
+		return fmt.Errorf("opening journal: %w", err)
+	}
+	defer file.Close()
+
+	for _, event := range events {
+		if err := writeEvent(file, event); err != nil {
+			return fmt.Errorf("appending event: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// ReadJournal streams every event recorded at or after since, oldest
+// first. A store with no journal yet yields nothing. Malformed records are
+// treated as the end of the journal rather than failing the whole read,
+// since there's no way to surface an error through iter.Seq.
+func ReadJournal(store Store, since time.Time) iter.Seq[Event] {
+	return func(yield func(Event) bool) {
+		file, err := store.Open(journalName)
+		if err != nil {
+			return
+		}
+		defer file.Close()
+
+		scanner := bufio.NewScanner(file)
+		record := make(map[string]string, 6)
+		flush := func() bool {
+			if len(record) == 0 {
+				return true
+			}
+
+			event, ok := eventFromRecord(record)
+			record = make(map[string]string, 6)
+			if !ok {
+				return false
+			}
+
+			if event.Timestamp.Before(since) {
+				return true
+			}
+
+			return yield(event)
+		}
+
+		for scanner.Scan() {
+			line := scanner.Text()
+			if line == "" {
+				if !flush() {
+					return
+				}
+				continue
+			}
+
+			key, value, ok := strings.Cut(line, ": ")
+			if !ok {
+				return
+			}
+			record[key] = value
+		}
+
+		flush()
+	}
+}
+
+// eventFromRecord converts a parsed "Key: value" record into an Event.
+func eventFromRecord(record map[string]string) (Event, bool) {
+	timestamp, err := time.Parse(time.RFC3339, record["Timestamp"])
+	if err != nil {
+		return Event{}, false
+	}
+
+	return Event{
+		Timestamp: timestamp,
+		ListID:    record["ListID"],
+		TodoID:    record["TodoID"],
+		Action:    Action(record["Action"]),
+		From:      record["From"],
+		To:        record["To"],
+	}, true
+}
+
+// diffEvents compares oldList against newList and returns the events
+// needed to bring the journal up to date: a todo completed, a todo
+// appearing or disappearing entirely, or (when exactly one todo appears
+// and one disappears within the same sublist) a rename. Stable IDs are
+// derived from a todo's name, so a rename can't be detected directly -
+// this heuristic is the closest approximation available.
+func diffEvents(oldList, newList List, at time.Time) []Event {
+	type located struct {
+		listID string
+		todo   Todo
+	}
+
+	byID := func(list List) map[string]located {
+		found := make(map[string]located)
+		if list.Sublists == nil {
+			return found
+		}
+
+		for listID, todos := range list.Sublists.EntryIter() {
+			for _, todo := range todos {
+				found[todo.ID] = located{listID: listID, todo: todo}
+			}
+		}
+
+		return found
+	}
+
+	oldByID := byID(oldList)
+	newByID := byID(newList)
+
+	var events []Event
+	var created, deleted []located
+
+	for id, n := range newByID {
+		o, existed := oldByID[id]
+		switch {
+		case !existed:
+			created = append(created, n)
+		case !o.todo.Completed && n.todo.Completed:
+			events = append(events, Event{Timestamp: at, ListID: n.listID, TodoID: id, Action: ActionCompleted})
+		}
+	}
+
+	for id, o := range oldByID {
+		if o.todo.Completed {
+			continue
+		}
+
+		if _, ok := newByID[id]; !ok {
+			deleted = append(deleted, o)
+		}
+	}
+
+	if len(created) == 1 && len(deleted) == 1 && created[0].listID == deleted[0].listID {
+		events = append(events, Event{
+			Timestamp: at,
+			ListID:    created[0].listID,
+			TodoID:    created[0].todo.ID,
+			Action:    ActionRenamed,
+			From:      deleted[0].todo.Name,
+			To:        created[0].todo.Name,
+		})
+
+		return events
+	}
+
+	for _, c := range created {
+		events = append(events, Event{Timestamp: at, ListID: c.listID, TodoID: c.todo.ID, Action: ActionCreated})
+	}
+
+	for _, d := range deleted {
+		events = append(events, Event{Timestamp: at, ListID: d.listID, TodoID: d.todo.ID, Action: ActionDeleted})
+	}
+
+	return events
+}
+
+// RecordEdits journals whatever changed between before and after, meant to
+// be called around a manual $EDITOR session on a single list.
+func RecordEdits(store Store, before, after List) error {
+	return appendEvents(store, diffEvents(before, after, time.Now()))
+}
@@ -0,0 +1,207 @@
+package drtodo
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/eriktate/go-ordmap"
+)
+
+// DetectCutoff walks store's lists from most recent to oldest and returns
+// the day after the first one found with nothing left unfinished. If no
+// list is ever fully completed, it returns the oldest list's date so
+// CreateTodaySince merges everything. It returns ErrListNotFound if store
+// has no lists at all.
+func DetectCutoff(store Store) (time.Time, error) {
+	names, err := GetSortedListPaths(store)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("getting sorted paths: %w", err)
+	}
+
+	if len(names) == 0 {
+		return time.Time{}, ErrListNotFound
+	}
+
+	var oldest time.Time
+	for _, name := range names {
+		ext := filepath.Ext(name)
+		date, err := ParseDate(strings.TrimSuffix(name, ext))
+		if err != nil {
+			return time.Time{}, fmt.Errorf("parsing date from '%s': %w", name, err)
+		}
+		oldest = date
+
+		format, err := FormatForExt(ext)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("determining format for '%s': %w", name, err)
+		}
+
+		file, err := store.Open(name)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("opening '%s': %w", name, err)
+		}
+
+		list, err := format.Parse(file)
+		file.Close()
+		if err != nil {
+			return time.Time{}, fmt.Errorf("parsing '%s': %w", name, err)
+		}
+
+		if listFullyCompleted(list) {
+			return date.Add(24 * time.Hour), nil
+		}
+	}
+
+	return oldest, nil
+}
+
+func listFullyCompleted(list List) bool {
+	if list.Sublists == nil {
+		return true
+	}
+
+	for _, todos := range list.Sublists.EntryIter() {
+		for _, todo := range todos {
+			if !todo.Completed {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// CreateTodaySince creates today's list by merging every list dated on or
+// after cutoff. Lists are walked newest first, and each todo ID (derived
+// from name+listID, see newTodo) is resolved by its most recent
+// occurrence: if that's completed, the todo is dropped entirely - even if
+// an older occurrence within the window is still unfinished - and if it's
+// still open, it's carried forward exactly once. The merged file is
+// written in the most recent included list's format, falling back to
+// dt.format if cutoff excludes every list.
+func (dt DrTodo) CreateTodaySince(cutoff time.Time) (string, error) {
+	// Lists are only ever dated at midnight (see ParseDate), so cutoff
+	// needs the same truncation or a cutoff with a nonzero time-of-day -
+	// e.g. time.Now().Add(-N*24*time.Hour), the obvious way to build one -
+	// would wrongly exclude the boundary day.
+	cutoff, err := ParseDate(FormatDate(cutoff))
+	if err != nil {
+		return "", fmt.Errorf("normalizing cutoff date: %w", err)
+	}
+
+	today := FormatDate(time.Now())
+
+	names, err := GetSortedListPaths(dt.store)
+	if err != nil {
+		return "", fmt.Errorf("getting sorted paths: %w", err)
+	}
+
+	idx, err := loadIndex(dt.store)
+	if err != nil {
+		return "", fmt.Errorf("loading index: %w", err)
+	}
+
+	format := dt.format
+	haveFormat := false
+	merged := ordmap.NewUnsafe[string, []Todo](10)
+	resolved := make(map[string]bool)
+	now := time.Now()
+	var events []Event
+
+	for _, name := range names {
+		ext := filepath.Ext(name)
+		date, err := ParseDate(strings.TrimSuffix(name, ext))
+		if err != nil {
+			return "", fmt.Errorf("parsing date from '%s': %w", name, err)
+		}
+
+		if date.Before(cutoff) {
+			break
+		}
+
+		listFormat, err := FormatForExt(ext)
+		if err != nil {
+			return "", fmt.Errorf("determining format for '%s': %w", name, err)
+		}
+
+		if !haveFormat {
+			format = listFormat
+			haveFormat = true
+		}
+
+		file, err := dt.store.Open(name)
+		if err != nil {
+			return "", fmt.Errorf("opening '%s': %w", name, err)
+		}
+
+		list, err := listFormat.Parse(file)
+		file.Close()
+		if err != nil {
+			return "", fmt.Errorf("parsing '%s': %w", name, err)
+		}
+
+		listName := strings.TrimSuffix(name, ext)
+		for listID, todos := range list.Sublists.EntryIter() {
+			for _, todo := range todos {
+				// names is sorted newest first, so the first occurrence of
+				// an ID we see is its most recent state; once resolved,
+				// older occurrences of the same todo must be ignored
+				// outright rather than re-merged or re-journaled.
+				if resolved[todo.ID] {
+					continue
+				}
+				resolved[todo.ID] = true
+
+				if todo.Completed {
+					idx[todo.ID] = append(idx[todo.ID], IndexEntry{ListName: listName, CompletedAt: &now})
+					events = append(events, Event{Timestamp: now, ListID: listID, TodoID: todo.ID, Action: ActionCompleted})
+					continue
+				}
+
+				action := ActionCreated
+				if len(idx[todo.ID]) > 0 {
+					action = ActionCarried
+				}
+				idx[todo.ID] = append(idx[todo.ID], IndexEntry{ListName: today})
+				events = append(events, Event{Timestamp: now, ListID: listID, TodoID: todo.ID, Action: action})
+
+				existing, _ := merged.Get(listID)
+				merged.Set(listID, append(existing, todo))
+			}
+		}
+	}
+
+	fname := fmt.Sprintf("%s.%s", today, format.Ext())
+
+	exists, err := dt.store.Stat(fname)
+	if err != nil {
+		return "", fmt.Errorf("checking if file exists: %w", err)
+	}
+
+	if exists {
+		return "", fmt.Errorf("file '%s' already exists", fname)
+	}
+
+	if err := saveIndex(dt.store, idx); err != nil {
+		return "", fmt.Errorf("saving index: %w", err)
+	}
+
+	mergedList := List{Name: fmt.Sprintf("TODO %s", today), Sublists: merged}
+	file, err := dt.store.Create(fname)
+	if err != nil {
+		return "", fmt.Errorf("creating new todo file: %w", err)
+	}
+	defer file.Close()
+
+	if err := format.Dump(file, mergedList, DumpOptions{OmitCompleted: true}); err != nil {
+		return "", fmt.Errorf("dumping merged list: %w", err)
+	}
+
+	if err := appendEvents(dt.store, events); err != nil {
+		return "", fmt.Errorf("appending journal events: %w", err)
+	}
+
+	return fname, nil
+}
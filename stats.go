@@ -0,0 +1,53 @@
+package drtodo
+
+import "time"
+
+// Stats summarizes completion activity recorded in the journal over a time
+// window.
+type Stats struct {
+	Completed         int
+	AvgTimeToComplete time.Duration
+	BySublist         map[string]int
+}
+
+// ComputeStats aggregates journal events into a Stats summary: how many
+// todos were completed on or after since, how long they took on average
+// from their earliest created/carried event to completion, and how many
+// completions landed in each sublist. Completions with no recorded
+// created/carried event still count towards Completed and BySublist but are
+// left out of the average. The full journal is walked regardless
+// of since so that a todo's start time is found even if it was created
+// before the window.
+func ComputeStats(store Store, since time.Time) (Stats, error) {
+	started := make(map[string]time.Time)
+	stats := Stats{BySublist: make(map[string]int)}
+	var total time.Duration
+	var timed int
+
+	for event := range ReadJournal(store, time.Time{}) {
+		switch event.Action {
+		case ActionCreated, ActionCarried:
+			if _, ok := started[event.TodoID]; !ok {
+				started[event.TodoID] = event.Timestamp
+			}
+		case ActionCompleted:
+			if event.Timestamp.Before(since) {
+				continue
+			}
+
+			stats.Completed++
+			stats.BySublist[event.ListID]++
+
+			if start, ok := started[event.TodoID]; ok {
+				total += event.Timestamp.Sub(start)
+				timed++
+			}
+		}
+	}
+
+	if timed > 0 {
+		stats.AvgTimeToComplete = total / time.Duration(timed)
+	}
+
+	return stats, nil
+}
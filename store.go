@@ -0,0 +1,113 @@
+package drtodo
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// Store abstracts the persistence layer backing dr-todo's list files. It
+// exists so that parsing and carry-forward logic never has to call os.*
+// directly, which keeps the door open for backends that don't live on a
+// local disk.
+type Store interface {
+	// Open returns a reader for the named entry.
+	Open(name string) (io.ReadCloser, error)
+	// Create returns a writer for the named entry, creating it if it
+	// doesn't already exist or truncating it if it does.
+	Create(name string) (io.WriteCloser, error)
+	// Append returns a writer that adds to the named entry's existing
+	// contents, creating it if it doesn't already exist.
+	Append(name string) (io.WriteCloser, error)
+	// List returns the names of every entry currently in the store.
+	List() ([]string, error)
+	// Stat reports whether the named entry exists.
+	Stat(name string) (bool, error)
+}
+
+// DiskStore is a Store backed by a directory on the local filesystem. It
+// reproduces dr-todo's original os.*-based behavior.
+type DiskStore struct {
+	root string
+}
+
+// NewDiskStore returns a DiskStore rooted at root. root itself is never
+// created by the DiskStore; Create and Append only create missing
+// subdirectories beneath it.
+func NewDiskStore(root string) *DiskStore {
+	return &DiskStore{root: root}
+}
+
+func (s *DiskStore) Open(name string) (io.ReadCloser, error) {
+	file, err := os.Open(filepath.Join(s.root, name))
+	if err != nil {
+		return nil, fmt.Errorf("opening '%s': %w", name, err)
+	}
+
+	return file, nil
+}
+
+func (s *DiskStore) Create(name string) (io.WriteCloser, error) {
+	path := filepath.Join(s.root, name)
+	if dir := filepath.Dir(path); dir != s.root {
+		if err := os.MkdirAll(dir, 0777); err != nil {
+			return nil, fmt.Errorf("creating parent dir for '%s': %w", name, err)
+		}
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return nil, fmt.Errorf("creating '%s': %w", name, err)
+	}
+
+	return file, nil
+}
+
+func (s *DiskStore) Append(name string) (io.WriteCloser, error) {
+	path := filepath.Join(s.root, name)
+	if dir := filepath.Dir(path); dir != s.root {
+		if err := os.MkdirAll(dir, 0777); err != nil {
+			return nil, fmt.Errorf("creating parent dir for '%s': %w", name, err)
+		}
+	}
+
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0666)
+	if err != nil {
+		return nil, fmt.Errorf("appending to '%s': %w", name, err)
+	}
+
+	return file, nil
+}
+
+func (s *DiskStore) List() ([]string, error) {
+	entries, err := os.ReadDir(s.root)
+	if err != nil {
+		return nil, fmt.Errorf("reading dir: %w", err)
+	}
+
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+
+		names = append(names, entry.Name())
+	}
+
+	return names, nil
+}
+
+func (s *DiskStore) Stat(name string) (bool, error) {
+	_, err := os.Stat(filepath.Join(s.root, name))
+	if err == nil {
+		return true, nil
+	}
+
+	if errors.Is(err, os.ErrNotExist) {
+		return false, nil
+	}
+
+	return false, fmt.Errorf("stat-ing '%s': %w", name, err)
+}
@@ -0,0 +1,100 @@
+package drtodo
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+)
+
+// GitStore is a Store backed by a local directory that's also a git
+// repository. Every Create commits the written file, so users get
+// automatic history of their lists for free.
+type GitStore struct {
+	*DiskStore
+	root string
+}
+
+// NewGitStore returns a GitStore rooted at root, running `git init` if root
+// isn't already a git repository.
+func NewGitStore(root string) (*GitStore, error) {
+	if _, err := os.Stat(filepath.Join(root, ".git")); err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("checking for git repo: %w", err)
+		}
+
+		cmd := exec.Command("git", "init")
+		cmd.Dir = root
+		if err := cmd.Run(); err != nil {
+			return nil, fmt.Errorf("initializing git repo in '%s': %w", root, err)
+		}
+	}
+
+	return &GitStore{DiskStore: NewDiskStore(root), root: root}, nil
+}
+
+func (s *GitStore) Create(name string) (io.WriteCloser, error) {
+	wc, err := s.DiskStore.Create(name)
+	if err != nil {
+		return nil, err
+	}
+
+	return &gitWriter{WriteCloser: wc, store: s, name: name}, nil
+}
+
+func (s *GitStore) Append(name string) (io.WriteCloser, error) {
+	wc, err := s.DiskStore.Append(name)
+	if err != nil {
+		return nil, err
+	}
+
+	return &gitWriter{WriteCloser: wc, store: s, name: name}, nil
+}
+
+// defaultAuthorArgs supplies a committer so GitStore works on a machine
+// with no global git identity configured, without touching the user's
+// actual git config.
+var defaultAuthorArgs = []string{"-c", "user.name=dr-todo", "-c", "user.email=dr-todo@localhost"}
+
+func (s *GitStore) commit(name string) error {
+	add := exec.Command("git", "add", name)
+	add.Dir = s.root
+	if err := add.Run(); err != nil {
+		return fmt.Errorf("staging '%s': %w", name, err)
+	}
+
+	diff := exec.Command("git", "diff", "--cached", "--quiet")
+	diff.Dir = s.root
+	if err := diff.Run(); err == nil {
+		// Nothing actually changed relative to HEAD (e.g. re-writing
+		// identical content), so there's nothing to commit.
+		return nil
+	}
+
+	args := append(append([]string{}, defaultAuthorArgs...), "commit", "-m", fmt.Sprintf("dr-todo: update %s", name))
+	commit := exec.Command("git", args...)
+	commit.Dir = s.root
+	if err := commit.Run(); err != nil {
+		return fmt.Errorf("committing '%s': %w", name, err)
+	}
+
+	return nil
+}
+
+// gitWriter commits the named file to the owning GitStore's repo once the
+// underlying write is closed.
+type gitWriter struct {
+	io.WriteCloser
+	store *GitStore
+	name  string
+}
+
+func (w *gitWriter) Close() error {
+	if err := w.WriteCloser.Close(); err != nil {
+		return err
+	}
+
+	return w.store.commit(w.name)
+}
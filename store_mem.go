@@ -0,0 +1,81 @@
+package drtodo
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"strings"
+)
+
+// MemStore is an in-memory Store, primarily meant to back tests that would
+// otherwise need to scaffold a temp directory.
+type MemStore struct {
+	files map[string][]byte
+}
+
+// NewMemStore returns an empty MemStore.
+func NewMemStore() *MemStore {
+	return &MemStore{files: make(map[string][]byte)}
+}
+
+func (s *MemStore) Open(name string) (io.ReadCloser, error) {
+	data, ok := s.files[name]
+	if !ok {
+		return nil, os.ErrNotExist
+	}
+
+	return io.NopCloser(bytes.NewReader(data)), nil
+}
+
+func (s *MemStore) Create(name string) (io.WriteCloser, error) {
+	return &memWriter{store: s, name: name}, nil
+}
+
+func (s *MemStore) Append(name string) (io.WriteCloser, error) {
+	return &memWriter{store: s, name: name, append: true}, nil
+}
+
+// List returns every top-level entry, skipping sidecar files kept under a
+// subdirectory (e.g. ".drtodo/index") to mirror DiskStore.List ignoring
+// subdirectories.
+func (s *MemStore) List() ([]string, error) {
+	names := make([]string, 0, len(s.files))
+	for name := range s.files {
+		if strings.Contains(name, "/") {
+			continue
+		}
+
+		names = append(names, name)
+	}
+
+	return names, nil
+}
+
+func (s *MemStore) Stat(name string) (bool, error) {
+	_, ok := s.files[name]
+	return ok, nil
+}
+
+// memWriter buffers writes and only commits them to the owning MemStore on
+// Close, matching the create-then-write-then-close lifecycle os.File gives
+// callers like List.Dump.
+type memWriter struct {
+	store  *MemStore
+	name   string
+	buf    bytes.Buffer
+	append bool
+}
+
+func (w *memWriter) Write(p []byte) (int, error) {
+	return w.buf.Write(p)
+}
+
+func (w *memWriter) Close() error {
+	if w.append {
+		w.store.files[w.name] = append(w.store.files[w.name], w.buf.Bytes()...)
+		return nil
+	}
+
+	w.store.files[w.name] = w.buf.Bytes()
+	return nil
+}
@@ -0,0 +1,28 @@
+package drtodo
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+)
+
+// newTodo builds a Todo with a stable, content-addressed ID so the same
+// item keeps its identity across days even though nothing on disk tracks
+// it explicitly.
+func newTodo(listID, name string, completed bool) Todo {
+	return Todo{
+		ID:        todoID(listID, name),
+		ListID:    listID,
+		Name:      name,
+		Completed: completed,
+	}
+}
+
+// todoID derives a stable ID from a todo's list hierarchy and name: the
+// first 8 bytes of a SHA-256 digest over the normalized name and list
+// path parts, hex-encoded.
+func todoID(listID, name string) string {
+	norm := strings.ToLower(strings.TrimSpace(name))
+	sum := sha256.Sum256([]byte(listID + listSep + norm))
+	return hex.EncodeToString(sum[:8])
+}